@@ -0,0 +1,72 @@
+// Package address resolves a Minecraft server address, accounting for the "_minecraft._tcp.<host>" SRV record
+// that Minecraft clients check before connecting. This is what makes subdomain-based hosting (and routing through
+// reverse proxies like Velocity/BungeeCord) work without requiring callers to know the real host and port.
+package address
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Target is a resolved Minecraft server address.
+//
+// Host and DialHost are deliberately distinct: Host is the hostname the caller originally asked to reach and is
+// what must be written into the Handshake packet's server address field so that vhost-based reverse proxies
+// (Velocity/BungeeCord) route the connection correctly. DialHost is who to actually open the TCP connection to,
+// which differs from Host when an SRV record points elsewhere (e.g. at a shared-hosting backend).
+type Target struct {
+	Host     string
+	DialHost string
+	Port     uint16
+}
+
+// Resolve parses addr, which may be a bare host, a "host:port" pair, or a domain that publishes a
+// "_minecraft._tcp.<host>" SRV record, into a dialable Target.
+//
+// An explicit port in addr always wins over SRV, and Host/DialHost are both set to addr's host part. Otherwise resolver is
+// queried for the SRV record; if one exists, DialHost and Port are taken from it (with the target's trailing dot
+// trimmed) while Host remains the original, unresolved hostname. If no SRV record exists, Host and DialHost both
+// fall back to addr with defaultPort, leaving normal A/AAAA resolution to happen at dial time. resolver may be
+// nil to use net.DefaultResolver.
+func Resolve(resolver *net.Resolver, addr string, defaultPort uint16) (Target, error) {
+	return ResolveHost(resolver, addr, defaultPort, true)
+}
+
+// ResolveHost is Resolve with SRV resolution made optional via trySRV, for callers that already know they don't
+// want it (e.g. a Client constructed with WithSRV(false)).
+func ResolveHost(resolver *net.Resolver, addr string, defaultPort uint16, trySRV bool) (Target, error) {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	if host, portStr, err := net.SplitHostPort(addr); err == nil {
+		port, err := strconv.ParseUint(portStr, 10, 16)
+		if err != nil {
+			return Target{}, err
+		}
+		return Target{Host: host, DialHost: host, Port: uint16(port)}, nil
+	}
+
+	host := addr
+
+	if trySRV {
+		// More information: https://wiki.vg/Server_List_Ping#Current (clients resolve _minecraft._tcp SRV records)
+		if _, srvs, err := resolver.LookupSRV(context.Background(), "minecraft", "tcp", host); err == nil && len(srvs) > 0 {
+			return Target{
+				Host:     host,
+				DialHost: trimTrailingDot(srvs[0].Target),
+				Port:     srvs[0].Port,
+			}, nil
+		}
+	}
+
+	return Target{Host: host, DialHost: host, Port: defaultPort}, nil
+}
+
+// trimTrailingDot strips the trailing "." an SRV target is returned with, since it's a DNS root marker rather
+// than part of the hostname callers (and the Handshake packet) expect.
+func trimTrailingDot(host string) string {
+	return strings.TrimSuffix(host, ".")
+}
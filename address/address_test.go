@@ -0,0 +1,60 @@
+package address
+
+import (
+	"net"
+	"testing"
+)
+
+func TestResolveHostExplicitPort(t *testing.T) {
+	target, err := ResolveHost(nil, "mc.example.com:25566", 25565, true)
+	if err != nil {
+		t.Fatalf("ResolveHost() error = %v", err)
+	}
+	if target.Host != "mc.example.com" || target.DialHost != "mc.example.com" || target.Port != 25566 {
+		t.Fatalf("ResolveHost() = %+v, want Host=DialHost=mc.example.com Port=25566", target)
+	}
+}
+
+func TestResolveHostNoSRVFallsBackTo25565(t *testing.T) {
+	target, err := ResolveHost(&net.Resolver{}, "127.0.0.1.nip.io", 25565, false)
+	if err != nil {
+		t.Fatalf("ResolveHost() error = %v", err)
+	}
+	if target.Host != "127.0.0.1.nip.io" || target.DialHost != target.Host || target.Port != 25565 {
+		t.Fatalf("ResolveHost() = %+v, want Host=DialHost=127.0.0.1.nip.io Port=%d", target, 25565)
+	}
+}
+
+// TestTrimTrailingDot covers the helper that strips an SRV target's trailing DNS root dot, which feeds directly
+// into Target.DialHost.
+func TestTrimTrailingDot(t *testing.T) {
+	cases := map[string]string{
+		"node7.sharedhost.net.": "node7.sharedhost.net",
+		"node7.sharedhost.net":  "node7.sharedhost.net",
+		"":                      "",
+	}
+
+	for in, want := range cases {
+		if got := trimTrailingDot(in); got != want {
+			t.Errorf("trimTrailingDot(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// TestTargetKeepsOriginalHostDistinctFromDialHost is a regression test: Target must be able to represent an SRV
+// answer pointing somewhere other than the original host, with Host (used for the Handshake's virtual host field)
+// left unchanged from what DialHost/Port resolve to.
+func TestTargetKeepsOriginalHostDistinctFromDialHost(t *testing.T) {
+	target := Target{
+		Host:     "play.example.com",
+		DialHost: trimTrailingDot("node7.sharedhost.net."),
+		Port:     30001,
+	}
+
+	if target.Host == target.DialHost {
+		t.Fatalf("Host and DialHost unexpectedly equal: %q", target.Host)
+	}
+	if target.Host != "play.example.com" {
+		t.Fatalf("Host = %q, want original hostname preserved", target.Host)
+	}
+}
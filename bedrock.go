@@ -0,0 +1,160 @@
+package minecraftping
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBedrockPort is the default Minecraft Bedrock Edition network port.
+	DefaultBedrockPort = 19132
+
+	unconnectedPing = 0x01
+	unconnectedPong = 0x1c
+)
+
+// offlineMessageDataID is the RakNet "magic" value used to identify offline messages.
+// More information: https://wiki.vg/Raknet_Protocol#Data_types
+var offlineMessageDataID = []byte{
+	0x00, 0xff, 0xff, 0x00, 0xfe, 0xfe, 0xfe, 0xfe, 0xfd, 0xfd, 0xfd, 0xfd, 0x12, 0x34, 0x56, 0x78,
+}
+
+// BedrockResponse is a representation of the Minecraft Bedrock Edition server's Unconnected Pong response.
+// More information: https://wiki.vg/Raknet_Protocol#Unconnected_Pong
+type BedrockResponse struct {
+	Edition         string
+	MOTDLine1       string
+	ProtocolVersion int
+	VersionName     string
+	PlayersOnline   int
+	PlayersMax      int
+	ServerGUID      int64
+	LevelName       string
+	Gamemode        string
+	// MOTDLine2 mirrors LevelName: most server software (Nukkit, PocketMine-MP, ...) reuses the same
+	// "level name" field of the server ID string as the second MOTD line.
+	MOTDLine2 string
+	IPv4Port  uint16
+	IPv6Port  uint16
+}
+
+// PingBedrock connects and pings the Minecraft Bedrock Edition server at the specified address and port using the
+// RakNet offline ping handshake. timeout bounds dialing the UDP socket and readTimeout bounds waiting for the
+// Unconnected Pong reply.
+// More information: https://wiki.vg/Raknet_Protocol#Unconnected_Ping
+func PingBedrock(address string, port uint16, timeout, readTimeout time.Duration) (BedrockResponse, error) {
+	var resp BedrockResponse
+
+	conn, err := net.DialTimeout("udp", address+":"+strconv.Itoa(int(port)), timeout)
+	if err != nil {
+		return resp, err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(readTimeout)); err != nil {
+		return resp, err
+	}
+
+	if _, err := conn.Write(makeUnconnectedPingPacket()); err != nil {
+		return resp, err
+	}
+
+	// The largest observed Unconnected Pong payloads fit comfortably within a single UDP datagram.
+	buf := make([]byte, 1492)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return resp, err
+	}
+
+	return parseUnconnectedPong(buf[:n])
+}
+
+func makeUnconnectedPingPacket() []byte {
+	var buf bytes.Buffer
+
+	buf.WriteByte(unconnectedPing)
+	binary.Write(&buf, binary.BigEndian, time.Now().UnixNano())
+	buf.Write(offlineMessageDataID)
+	binary.Write(&buf, binary.BigEndian, rand.Int63())
+
+	return buf.Bytes()
+}
+
+func parseUnconnectedPong(packet []byte) (BedrockResponse, error) {
+	var resp BedrockResponse
+
+	reader := bytes.NewReader(packet)
+
+	packetId, err := reader.ReadByte()
+	if err != nil {
+		return resp, err
+	}
+	if packetId != unconnectedPong {
+		return resp, fmt.Errorf("received invalid packetId (expected %#x!) %#x", unconnectedPong, packetId)
+	}
+
+	// Skip the server timestamp.
+	if _, err := reader.Seek(8, 1); err != nil {
+		return resp, err
+	}
+
+	if err := binary.Read(reader, binary.BigEndian, &resp.ServerGUID); err != nil {
+		return resp, err
+	}
+
+	// Skip the echoed magic value.
+	if _, err := reader.Seek(int64(len(offlineMessageDataID)), 1); err != nil {
+		return resp, err
+	}
+
+	var length uint16
+	if err := binary.Read(reader, binary.BigEndian, &length); err != nil {
+		return resp, err
+	}
+
+	serverId := make([]byte, length)
+	if _, err := io.ReadFull(reader, serverId); err != nil {
+		return resp, err
+	}
+
+	parseServerIdString(string(serverId), &resp)
+
+	return resp, nil
+}
+
+// parseServerIdString parses the semicolon-delimited "server ID string" returned by an Unconnected Pong into resp.
+// More information: https://wiki.vg/Raknet_Protocol#Unconnected_Pong
+func parseServerIdString(serverId string, resp *BedrockResponse) {
+	fields := strings.Split(serverId, ";")
+
+	get := func(i int) string {
+		if i < len(fields) {
+			return fields[i]
+		}
+		return ""
+	}
+
+	resp.Edition = get(0)
+	resp.MOTDLine1 = get(1)
+	resp.ProtocolVersion, _ = strconv.Atoi(get(2))
+	resp.VersionName = get(3)
+	resp.PlayersOnline, _ = strconv.Atoi(get(4))
+	resp.PlayersMax, _ = strconv.Atoi(get(5))
+	// get(6) is the server's unique ID, already parsed off the fixed-width header above.
+	resp.LevelName = get(7)
+	resp.MOTDLine2 = get(7)
+	resp.Gamemode = get(8)
+	if v, err := strconv.ParseUint(get(10), 10, 16); err == nil {
+		resp.IPv4Port = uint16(v)
+	}
+	if v, err := strconv.ParseUint(get(11), 10, 16); err == nil {
+		resp.IPv6Port = uint16(v)
+	}
+}
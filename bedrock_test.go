@@ -0,0 +1,71 @@
+package minecraftping
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func buildUnconnectedPong(t *testing.T, serverGUID int64, serverId string, truncate int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	buf.WriteByte(unconnectedPong)
+	binary.Write(&buf, binary.BigEndian, int64(0)) // server timestamp, unused by the parser
+	binary.Write(&buf, binary.BigEndian, serverGUID)
+	buf.Write(offlineMessageDataID)
+	binary.Write(&buf, binary.BigEndian, uint16(len(serverId)))
+	buf.WriteString(serverId)
+
+	packet := buf.Bytes()
+	if truncate > 0 && truncate < len(packet) {
+		packet = packet[:len(packet)-truncate]
+	}
+
+	return packet
+}
+
+func TestParseUnconnectedPong(t *testing.T) {
+	serverId := "MCPE;A Server;498;1.14.60;3;20;1234567890;Level;Survival;1;19132;19133"
+
+	resp, err := parseUnconnectedPong(buildUnconnectedPong(t, 1234567890, serverId, 0))
+	if err != nil {
+		t.Fatalf("parseUnconnectedPong() error = %v", err)
+	}
+
+	if resp.Edition != "MCPE" || resp.VersionName != "1.14.60" || resp.PlayersOnline != 3 || resp.PlayersMax != 20 {
+		t.Fatalf("parseUnconnectedPong() = %+v, unexpected fields", resp)
+	}
+	if resp.IPv4Port != 19132 || resp.IPv6Port != 19133 {
+		t.Fatalf("parseUnconnectedPong() ports = %d/%d, want 19132/19133", resp.IPv4Port, resp.IPv6Port)
+	}
+}
+
+// TestParseUnconnectedPongTruncated is a regression test: a pong whose declared server ID string length exceeds
+// the bytes actually present must fail loudly, not silently return a partially-zeroed BedrockResponse.
+func TestParseUnconnectedPongTruncated(t *testing.T) {
+	packet := buildUnconnectedPong(t, 1, "MCPE;A Server;498;1.14.60;3;20;1;Level;Survival;1;19132;19133", 40)
+
+	if _, err := parseUnconnectedPong(packet); err == nil {
+		t.Fatal("parseUnconnectedPong() on truncated packet error = nil, want a read error")
+	}
+}
+
+// TestMakeUnconnectedPingPacketDistinctGUID is a regression test: the client timestamp and client GUID fields
+// must not be the same value written twice.
+func TestMakeUnconnectedPingPacketDistinctGUID(t *testing.T) {
+	packet := makeUnconnectedPingPacket()
+
+	wantLen := 1 + 8 + len(offlineMessageDataID) + 8
+	if len(packet) != wantLen {
+		t.Fatalf("len(packet) = %d, want %d", len(packet), wantLen)
+	}
+
+	timestamp := int64(binary.BigEndian.Uint64(packet[1:9]))
+	guidOffset := 1 + 8 + len(offlineMessageDataID)
+	clientGUID := int64(binary.BigEndian.Uint64(packet[guidOffset : guidOffset+8]))
+
+	if timestamp == clientGUID {
+		t.Fatalf("client timestamp and client GUID are identical (%d); GUID must be independently generated", timestamp)
+	}
+}
@@ -0,0 +1,189 @@
+// Package chat parses and renders Minecraft Java Edition chat components: the JSON text format used for
+// Response.Description, disconnect reasons, and in-game chat generally.
+// More information: https://wiki.vg/Chat
+package chat
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Component is a single node of a Minecraft chat component tree.
+// More information: https://wiki.vg/Chat#Current_system_.28JSON_Chat.29
+type Component struct {
+	Text      string `json:"text,omitempty"`
+	Translate string `json:"translate,omitempty"`
+
+	Color         string `json:"color,omitempty"`
+	Bold          bool   `json:"bold,omitempty"`
+	Italic        bool   `json:"italic,omitempty"`
+	Underlined    bool   `json:"underlined,omitempty"`
+	Strikethrough bool   `json:"strikethrough,omitempty"`
+	Obfuscated    bool   `json:"obfuscated,omitempty"`
+
+	ClickEvent *ClickEvent `json:"clickEvent,omitempty"`
+	HoverEvent *HoverEvent `json:"hoverEvent,omitempty"`
+
+	Extra []Component `json:"extra,omitempty"`
+}
+
+// ClickEvent describes what happens when a component is clicked in the Minecraft client.
+type ClickEvent struct {
+	Action string `json:"action"`
+	Value  string `json:"value"`
+}
+
+// HoverEvent describes what's shown when a component is hovered in the Minecraft client.
+type HoverEvent struct {
+	Action   string          `json:"action"`
+	Value    json.RawMessage `json:"value,omitempty"`
+	Contents json.RawMessage `json:"contents,omitempty"`
+}
+
+// componentAlias is Component without its UnmarshalJSON method, used to decode the object form without recursing.
+type componentAlias Component
+
+// UnmarshalJSON accepts both the legacy plain-string form of a chat component and the modern object/array form.
+func (c *Component) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*c = Component{Text: s}
+		return nil
+	}
+
+	var list []Component
+	if err := json.Unmarshal(data, &list); err == nil {
+		if len(list) == 0 {
+			*c = Component{}
+			return nil
+		}
+		*c = list[0]
+		c.Extra = append(c.Extra, list[1:]...)
+		return nil
+	}
+
+	var alias componentAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+	*c = Component(alias)
+
+	return nil
+}
+
+// PlainText recursively flattens the component tree into a single string, resolving (by stripping) legacy
+// "§"-style formatting codes in Text.
+func (c Component) PlainText() string {
+	var sb strings.Builder
+	c.writePlainText(&sb)
+	return sb.String()
+}
+
+func (c Component) writePlainText(sb *strings.Builder) {
+	sb.WriteString(stripLegacyCodes(c.text()))
+
+	for _, extra := range c.Extra {
+		extra.writePlainText(sb)
+	}
+}
+
+// text returns Text, falling back to the raw Translate key when Text is empty. Translate-only components are the
+// common case for disconnect/kick reasons (e.g. "multiplayer.disconnect.outdated_client"), which have no
+// server-sent fallback string to render in their place.
+func (c Component) text() string {
+	if c.Text != "" {
+		return c.Text
+	}
+	return c.Translate
+}
+
+// ansiColors maps the named chat colors to their closest ANSI SGR foreground code.
+// More information: https://wiki.vg/Chat#Colors
+var ansiColors = map[string]string{
+	"black":        "30",
+	"dark_blue":    "34",
+	"dark_green":   "32",
+	"dark_aqua":    "36",
+	"dark_red":     "31",
+	"dark_purple":  "35",
+	"gold":         "33",
+	"gray":         "37",
+	"dark_gray":    "90",
+	"blue":         "94",
+	"green":        "92",
+	"aqua":         "96",
+	"red":          "91",
+	"light_purple": "95",
+	"yellow":       "93",
+	"white":        "97",
+}
+
+// ansiStyle is the cascading set of formatting flags applied while walking the component tree for ANSI.
+type ansiStyle struct {
+	color                                   string
+	bold, italic, underlined, strikethrough bool
+}
+
+// ANSI renders the component tree to a string with ANSI escape codes, suitable for terminal display.
+func (c Component) ANSI() string {
+	var sb strings.Builder
+	c.writeANSI(&sb, ansiStyle{})
+	return sb.String()
+}
+
+func (c Component) writeANSI(sb *strings.Builder, style ansiStyle) {
+	if c.Color != "" {
+		style.color = c.Color
+	}
+	style.bold = style.bold || c.Bold
+	style.italic = style.italic || c.Italic
+	style.underlined = style.underlined || c.Underlined
+	style.strikethrough = style.strikethrough || c.Strikethrough
+
+	var codes []string
+	if code, ok := ansiColors[style.color]; ok {
+		codes = append(codes, code)
+	}
+	if style.bold {
+		codes = append(codes, "1")
+	}
+	if style.italic {
+		codes = append(codes, "3")
+	}
+	if style.underlined {
+		codes = append(codes, "4")
+	}
+	if style.strikethrough {
+		codes = append(codes, "9")
+	}
+
+	if text := stripLegacyCodes(c.text()); text != "" {
+		if len(codes) > 0 {
+			sb.WriteString("\x1b[" + strings.Join(codes, ";") + "m")
+			sb.WriteString(text)
+			sb.WriteString("\x1b[0m")
+		} else {
+			sb.WriteString(text)
+		}
+	}
+
+	for _, extra := range c.Extra {
+		extra.writeANSI(sb, style)
+	}
+}
+
+// stripLegacyCodes removes legacy "§"-prefixed formatting codes (e.g. "§c" for red) from s.
+func stripLegacyCodes(s string) string {
+	runes := []rune(s)
+
+	out := make([]rune, 0, len(runes))
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '§' && i+1 < len(runes) {
+			i++
+			continue
+		}
+		out = append(out, runes[i])
+	}
+
+	return string(out)
+}
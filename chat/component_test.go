@@ -0,0 +1,65 @@
+package chat
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestComponentUnmarshalLegacyString(t *testing.T) {
+	var c Component
+	if err := c.UnmarshalJSON([]byte(`"A Server"`)); err != nil {
+		t.Fatalf("UnmarshalJSON() error = %v", err)
+	}
+	if c.Text != "A Server" {
+		t.Fatalf("Text = %q, want %q", c.Text, "A Server")
+	}
+}
+
+func TestComponentUnmarshalObject(t *testing.T) {
+	var c Component
+	data := []byte(`{"text":"Hello","color":"red","extra":[{"text":" world"}]}`)
+	if err := c.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON() error = %v", err)
+	}
+	if c.Text != "Hello" || c.Color != "red" || len(c.Extra) != 1 || c.Extra[0].Text != " world" {
+		t.Fatalf("UnmarshalJSON() = %+v, unexpected result", c)
+	}
+}
+
+// TestPlainTextFallsBackToTranslate is a regression test: translate-only components (the common case for kick and
+// disconnect reasons) previously rendered as an empty string since only Text was consulted.
+func TestPlainTextFallsBackToTranslate(t *testing.T) {
+	c := Component{Translate: "multiplayer.disconnect.outdated_client"}
+
+	if got := c.PlainText(); got != "multiplayer.disconnect.outdated_client" {
+		t.Fatalf("PlainText() = %q, want translate key as fallback", got)
+	}
+}
+
+func TestANSIFallsBackToTranslate(t *testing.T) {
+	c := Component{Translate: "multiplayer.disconnect.outdated_server", Color: "red"}
+
+	got := c.ANSI()
+	if got == "" {
+		t.Fatal("ANSI() = \"\", want rendered translate key")
+	}
+	if !strings.Contains(got, "multiplayer.disconnect.outdated_server") {
+		t.Fatalf("ANSI() = %q, want it to contain the translate key", got)
+	}
+}
+
+func TestPlainTextPrefersTextOverTranslate(t *testing.T) {
+	c := Component{Text: "Outdated client!", Translate: "multiplayer.disconnect.outdated_client"}
+
+	if got := c.PlainText(); got != "Outdated client!" {
+		t.Fatalf("PlainText() = %q, want Text to take priority over Translate", got)
+	}
+}
+
+func TestPlainTextStripsLegacyCodes(t *testing.T) {
+	c := Component{Text: "§cRed §lBold"}
+
+	if got := c.PlainText(); got != "Red Bold" {
+		t.Fatalf("PlainText() = %q, want legacy codes stripped", got)
+	}
+}
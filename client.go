@@ -0,0 +1,253 @@
+package minecraftping
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/Cryptkeeper/go-minecraftping/address"
+	"github.com/Cryptkeeper/go-minecraftping/internal/protocol"
+)
+
+// ConnState is the state of a Client's underlying connection, mirroring the Minecraft protocol's own connection
+// states. It exists so that calling a method out of order (e.g. Status twice) fails fast with a clear error
+// instead of hanging on a read the server will never answer.
+type ConnState int
+
+const (
+	StateIdle ConnState = iota
+	StateConnected
+	StateHandshakeSent
+	StateStatusReceived
+)
+
+func (s ConnState) String() string {
+	switch s {
+	case StateIdle:
+		return "idle"
+	case StateConnected:
+		return "connected"
+	case StateHandshakeSent:
+		return "handshake-sent"
+	case StateStatusReceived:
+		return "status-received"
+	default:
+		return "unknown"
+	}
+}
+
+// defaultClientTimeout is used when no WithTimeout option is given to NewClient.
+const defaultClientTimeout = 15 * time.Second
+
+// Client is a Minecraft Java Edition connection that can be driven through several request/response round trips
+// (status, ping, ...) without redialing, and can have its dialer, timeout and virtual host customized. Use
+// NewClient to construct one.
+type Client struct {
+	timeout         time.Duration
+	dialer          func(ctx context.Context, network, addr string) (net.Conn, error)
+	protocolVersion int
+	virtualHost     string
+	useSRV          bool
+	ctx             context.Context
+
+	dialHost string
+	port     uint16
+
+	conn   net.Conn
+	reader *bufio.Reader
+	state  ConnState
+}
+
+// ClientOption configures a Client constructed by NewClient.
+type ClientOption func(*Client)
+
+// WithTimeout sets the timeout applied to dialing and to each subsequent read/write.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) { c.timeout = timeout }
+}
+
+// WithDialer overrides how the Client opens its TCP connection, e.g. to dial through a SOCKS5 or Tor proxy.
+func WithDialer(dialer func(ctx context.Context, network, addr string) (net.Conn, error)) ClientOption {
+	return func(c *Client) { c.dialer = dialer }
+}
+
+// WithProtocolVersion sets the protocol version written into the Handshake packet.
+func WithProtocolVersion(protocolVersion int) ClientOption {
+	return func(c *Client) { c.protocolVersion = protocolVersion }
+}
+
+// WithVirtualHost overrides the server address written into the Handshake packet, independent of the host actually
+// dialed. This is needed to reach a specific backend behind a reverse proxy such as Velocity or BungeeCord.
+func WithVirtualHost(host string) ClientOption {
+	return func(c *Client) { c.virtualHost = host }
+}
+
+// WithSRV toggles "_minecraft._tcp" SRV resolution of the address passed to NewClient. Enabled by default.
+func WithSRV(enabled bool) ClientOption {
+	return func(c *Client) { c.useSRV = enabled }
+}
+
+// WithContext sets the context used to dial the connection, allowing cancellation independent of WithTimeout.
+func WithContext(ctx context.Context) ClientOption {
+	return func(c *Client) { c.ctx = ctx }
+}
+
+// NewClient resolves addr and dials a Minecraft Java Edition server, returning a Client ready to have Status
+// and/or Ping called on it.
+func NewClient(addr string, opts ...ClientOption) (*Client, error) {
+	c := &Client{
+		timeout:         defaultClientTimeout,
+		protocolVersion: LatestProtocolVersion,
+		useSRV:          true,
+		ctx:             context.Background(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	target, err := address.ResolveHost(nil, addr, DefaultPort, c.useSRV)
+	if err != nil {
+		return nil, err
+	}
+	c.dialHost, c.port = target.DialHost, target.Port
+
+	if c.virtualHost == "" {
+		c.virtualHost = target.Host
+	}
+
+	dial := c.dialer
+	if dial == nil {
+		var d net.Dialer
+		dial = d.DialContext
+	}
+
+	ctx := c.ctx
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	conn, err := dial(ctx, "tcp", protocol.Addr(c.dialHost, c.port))
+	if err != nil {
+		return nil, err
+	}
+
+	c.conn = conn
+	c.reader = bufio.NewReader(conn)
+	c.state = StateConnected
+
+	return c, nil
+}
+
+// Status sends the Handshake (if not already sent) and Request packets and returns the server's status Response.
+// It returns an error if Status has already been called on this connection; open a new Client to request status
+// again.
+func (c *Client) Status() (Response, error) {
+	var resp Response
+
+	if c.conn == nil {
+		return resp, errors.New("minecraftping: client is not connected")
+	}
+	if c.state == StateStatusReceived {
+		return resp, errors.New("minecraftping: status already received on this connection")
+	}
+
+	if err := c.conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		return resp, err
+	}
+
+	if c.state == StateConnected {
+		handshake := protocol.MakeHandshakePacket(c.virtualHost, c.port, c.protocolVersion, protocol.NextStateStatus)
+		if _, err := c.conn.Write(handshake); err != nil {
+			return resp, err
+		}
+		if _, err := c.conn.Write(requestPacket); err != nil {
+			return resp, err
+		}
+		c.state = StateHandshakeSent
+	}
+
+	packetId, payload, err := protocol.ReadPacket(c.reader)
+	if err != nil {
+		return resp, err
+	}
+	if packetId != 0 {
+		return resp, fmt.Errorf("received invalid packetId (expected 0!) %d", packetId)
+	}
+
+	jsonPayload, err := protocol.ReadVarIntPrefixedBytes(payload)
+	if err != nil {
+		return resp, err
+	}
+	if err := json.Unmarshal(jsonPayload, &resp); err != nil {
+		return resp, err
+	}
+
+	c.state = StateStatusReceived
+
+	return resp, nil
+}
+
+// Ping measures round-trip latency to the server using the Status state's Ping/Pong packets. Status must be
+// called first so the connection has completed its Handshake.
+// More information: https://wiki.vg/Server_List_Ping#Ping
+func (c *Client) Ping() (time.Duration, error) {
+	if c.conn == nil {
+		return 0, errors.New("minecraftping: client is not connected")
+	}
+	if c.state == StateIdle || c.state == StateConnected {
+		return 0, errors.New("minecraftping: Status must be called before Ping")
+	}
+
+	if err := c.conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		return 0, err
+	}
+
+	payload := time.Now().UnixNano()
+
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, payload)
+
+	start := time.Now()
+	if _, err := c.conn.Write(protocol.MakePacket(1, buf.Bytes())); err != nil {
+		return 0, err
+	}
+
+	packetId, respPayload, err := protocol.ReadPacket(c.reader)
+	if err != nil {
+		return 0, err
+	}
+	if packetId != 1 {
+		return 0, fmt.Errorf("received invalid packetId (expected 1!) %d", packetId)
+	}
+
+	var echoed int64
+	if err := binary.Read(bytes.NewReader(respPayload), binary.BigEndian, &echoed); err != nil {
+		return 0, err
+	}
+	if echoed != payload {
+		return 0, errors.New("minecraftping: pong payload did not match ping payload")
+	}
+
+	return time.Since(start), nil
+}
+
+// Close closes the underlying connection. It is safe to call more than once.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+
+	err := c.conn.Close()
+	c.conn = nil
+	c.state = StateIdle
+
+	return err
+}
@@ -0,0 +1,123 @@
+package minecraftping
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Cryptkeeper/go-minecraftping/internal/protocol"
+)
+
+func TestClientStatusCalledTwice(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	c := &Client{
+		conn:   client,
+		reader: bufio.NewReader(client),
+		state:  StateStatusReceived,
+	}
+
+	if _, err := c.Status(); err == nil {
+		t.Fatal("Status() error = nil, want already-received error")
+	}
+}
+
+func TestClientPingBeforeStatus(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	c := &Client{
+		conn:   client,
+		reader: bufio.NewReader(client),
+		state:  StateConnected,
+	}
+
+	if _, err := c.Ping(); err == nil {
+		t.Fatal("Ping() error = nil, want Status-must-be-called-first error")
+	}
+}
+
+// TestClientStatusAndPingRoundTrip drives a Client over a net.Pipe against a hand-rolled server goroutine,
+// exercising Status (handshake + status response) followed by Ping (ping/pong latency) on the same connection.
+func TestClientStatusAndPingRoundTrip(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	const description = `{"text":"A Server"}`
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- serveStatusAndPing(server, description)
+	}()
+
+	c := &Client{
+		timeout:         time.Second,
+		protocolVersion: LatestProtocolVersion,
+		virtualHost:     "localhost",
+		port:            25565,
+		conn:            client,
+		reader:          bufio.NewReader(client),
+		state:           StateConnected,
+	}
+
+	resp, err := c.Status()
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+
+	component, err := resp.DescriptionComponent()
+	if err != nil {
+		t.Fatalf("DescriptionComponent() error = %v", err)
+	}
+	if component.PlainText() != "A Server" {
+		t.Fatalf("DescriptionComponent().PlainText() = %q, want %q", component.PlainText(), "A Server")
+	}
+
+	if _, err := c.Ping(); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+
+	if err := <-errCh; err != nil {
+		t.Fatalf("server goroutine error = %v", err)
+	}
+}
+
+// serveStatusAndPing plays the server side of a Handshake+Request+Ping round trip: it reads (and discards) the
+// Handshake and Request packets, replies with a status response embedding description as its "description" field,
+// then echoes back whatever Ping payload it receives.
+func serveStatusAndPing(conn net.Conn, description string) error {
+	reader := bufio.NewReader(conn)
+
+	if _, _, err := protocol.ReadPacket(reader); err != nil { // Handshake
+		return err
+	}
+	if _, _, err := protocol.ReadPacket(reader); err != nil { // Request
+		return err
+	}
+
+	status := `{"description":` + description + `}`
+
+	var body bytes.Buffer
+	protocol.PutString(&body, status)
+	if _, err := conn.Write(protocol.MakePacket(0, body.Bytes())); err != nil {
+		return err
+	}
+
+	packetId, payload, err := protocol.ReadPacket(reader)
+	if err != nil {
+		return err
+	}
+	if packetId != 1 {
+		return nil
+	}
+
+	_, err = conn.Write(protocol.MakePacket(1, payload))
+	return err
+}
+
@@ -0,0 +1,212 @@
+// Package fingerprint guesses the Minecraft Java Edition server software (Vanilla, Paper, Forge, Velocity, ...)
+// behind a Ping response. It performs extra probes beyond a plain status request, so it lives outside the main
+// minecraftping package and must be opted into explicitly.
+package fingerprint
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/Cryptkeeper/go-minecraftping"
+	"github.com/Cryptkeeper/go-minecraftping/internal/protocol"
+)
+
+// Software identifies a guessed Minecraft server implementation.
+type Software string
+
+const (
+	SoftwareUnknown     Software = "unknown"
+	SoftwareVanilla     Software = "vanilla"
+	SoftwarePaper       Software = "paper"
+	SoftwareSpigot      Software = "spigot"
+	SoftwareCraftBukkit Software = "craftbukkit"
+	SoftwareForge       Software = "forge"
+	SoftwareFabric      Software = "fabric"
+	SoftwareVelocity    Software = "velocity"
+	SoftwareBungeeCord  Software = "bungeecord"
+	SoftwareWaterfall   Software = "waterfall"
+	SoftwareGeyser      Software = "geyser"
+)
+
+// probeUsername is sent as the Login Start username when probing a server. It's deliberately implausible so it
+// can't collide with a real player and is never actually used to complete a login.
+const probeUsername = "go-minecraftping-probe"
+
+// Result is the outcome of guessing a server's software.
+type Result struct {
+	Software   Software
+	Confidence float64
+	Mods       []string
+	Evidence   []string
+}
+
+// Fingerprint connects to the Minecraft Java Edition server at the specified address and port and guesses its
+// software by combining the status payload with a login-state probe.
+func Fingerprint(address string, port uint16, timeout time.Duration) (Result, error) {
+	var fp Result
+
+	resp, err := minecraftping.Ping(address, port, minecraftping.LatestProtocolVersion, timeout)
+	if err != nil {
+		return fp, err
+	}
+
+	fp.Evidence = append(fp.Evidence, versionNameEvidence(resp.Version.Name)...)
+
+	if mods, ok := forgeModInfoEvidence(resp); ok {
+		fp.Mods = mods
+		fp.Evidence = append(fp.Evidence, "forgeData/modinfo present")
+	}
+
+	if reason, err := loginProbe(address, port, timeout, minecraftping.LatestProtocolVersion); err == nil && reason != "" {
+		fp.Evidence = append(fp.Evidence, reason)
+	}
+
+	// An intentionally wrong protocol version often surfaces a more specific mismatch message
+	// (e.g. vanilla's "multiplayer.disconnect.outdated_client" translation key).
+	if reason, err := loginProbe(address, port, timeout, 2); err == nil && reason != "" {
+		fp.Evidence = append(fp.Evidence, reason)
+	}
+
+	fp.Software, fp.Confidence = classify(fp.Evidence, len(fp.Mods) > 0)
+
+	return fp, nil
+}
+
+// versionNameEvidence scans a status response's Version.Name for well-known software substrings.
+func versionNameEvidence(name string) []string {
+	var evidence []string
+
+	for _, needle := range []string{"Paper", "Spigot", "CraftBukkit", "Velocity", "Waterfall", "BungeeCord", "Fabric", "Forge", "Geyser"} {
+		if strings.Contains(name, needle) {
+			evidence = append(evidence, "version name contains "+needle)
+		}
+	}
+
+	return evidence
+}
+
+// forgeModInfoEvidence extracts the mod ID list from the legacy "modinfo" status field, if present.
+func forgeModInfoEvidence(resp minecraftping.Response) ([]string, bool) {
+	if len(resp.ForgeData) > 0 {
+		return nil, true
+	}
+
+	if len(resp.ModInfo) == 0 {
+		return nil, false
+	}
+
+	var modInfo struct {
+		ModList []struct {
+			ModId string `json:"modid"`
+		} `json:"modList"`
+	}
+	if err := json.Unmarshal(resp.ModInfo, &modInfo); err != nil {
+		return nil, true
+	}
+
+	mods := make([]string, 0, len(modInfo.ModList))
+	for _, mod := range modInfo.ModList {
+		mods = append(mods, mod.ModId)
+	}
+
+	return mods, true
+}
+
+// loginProbe opens a fresh connection, performs a Handshake into the Login state and sends a Login Start packet
+// with a fabricated username, then returns the disconnect reason sent back by the server, if any.
+func loginProbe(address string, port uint16, timeout time.Duration, protocolVersion int) (string, error) {
+	conn, err := net.DialTimeout("tcp", protocol.Addr(address, port), timeout)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return "", err
+	}
+
+	if _, err := conn.Write(protocol.MakeHandshakePacket(address, port, protocolVersion, protocol.NextStateLogin)); err != nil {
+		return "", err
+	}
+
+	var name bytes.Buffer
+	protocol.PutString(&name, probeUsername)
+	if _, err := conn.Write(protocol.MakePacket(0, name.Bytes())); err != nil {
+		return "", err
+	}
+
+	reader := bufio.NewReader(conn)
+
+	packetId, payload, err := protocol.ReadPacket(reader)
+	if err != nil {
+		return "", err
+	}
+
+	// Packet ID 0x00 during the Login state is Disconnect; anything else isn't a kick we can read a reason from.
+	if packetId != 0 {
+		return "", nil
+	}
+
+	reasonJSON, err := protocol.ReadVarIntPrefixedBytes(payload)
+	if err != nil {
+		return "", err
+	}
+
+	return disconnectReasonText(reasonJSON), nil
+}
+
+// disconnectReasonText extracts a human-readable string from a Disconnect packet's chat component JSON, which may
+// be a bare string or an object with a "text" field.
+func disconnectReasonText(raw []byte) string {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+
+	var component struct {
+		Text      string `json:"text"`
+		Translate string `json:"translate"`
+	}
+	if err := json.Unmarshal(raw, &component); err == nil {
+		if component.Text != "" {
+			return component.Text
+		}
+		return component.Translate
+	}
+
+	return string(raw)
+}
+
+// classify turns collected evidence strings into a best-guess Software and a rough [0,1] confidence score.
+func classify(evidence []string, hasMods bool) (Software, float64) {
+	joined := strings.ToLower(strings.Join(evidence, " "))
+
+	switch {
+	case strings.Contains(joined, "velocity"):
+		return SoftwareVelocity, 0.9
+	case strings.Contains(joined, "waterfall"):
+		return SoftwareWaterfall, 0.9
+	case strings.Contains(joined, "ip forwarding") || strings.Contains(joined, "bungeecord"):
+		return SoftwareBungeeCord, 0.8
+	case strings.Contains(joined, "geyser"):
+		return SoftwareGeyser, 0.8
+	case strings.Contains(joined, "fabric"):
+		return SoftwareFabric, 0.8
+	case strings.Contains(joined, "forge") || hasMods:
+		return SoftwareForge, 0.8
+	case strings.Contains(joined, "paper"):
+		return SoftwarePaper, 0.8
+	case strings.Contains(joined, "spigot"):
+		return SoftwareSpigot, 0.7
+	case strings.Contains(joined, "craftbukkit"):
+		return SoftwareCraftBukkit, 0.7
+	case strings.Contains(joined, "multiplayer.disconnect") || strings.Contains(joined, "outdated"):
+		return SoftwareVanilla, 0.5
+	default:
+		return SoftwareUnknown, 0
+	}
+}
@@ -0,0 +1,106 @@
+package fingerprint
+
+import (
+	"encoding/json"
+	"testing"
+
+	minecraftping "github.com/Cryptkeeper/go-minecraftping"
+)
+
+// TestClassifyPrecedence is a regression test: a proxy's own evidence (e.g. Velocity's IP forwarding disclaimer)
+// must win over the generic vanilla "multiplayer.disconnect"/"outdated" fallback, even when both are present in
+// the same evidence set (a proxied vanilla backend will surface both).
+func TestClassifyPrecedence(t *testing.T) {
+	evidence := []string{
+		"If you wish to use IP forwarding, please enable it in your Velocity config",
+		"multiplayer.disconnect.outdated_client",
+	}
+
+	software, confidence := classify(evidence, false)
+	if software != SoftwareVelocity {
+		t.Fatalf("classify() software = %v, want %v", software, SoftwareVelocity)
+	}
+	if confidence <= 0 {
+		t.Fatalf("classify() confidence = %v, want > 0", confidence)
+	}
+}
+
+func TestClassifyFallsBackToVanilla(t *testing.T) {
+	software, _ := classify([]string{"multiplayer.disconnect.outdated_client"}, false)
+	if software != SoftwareVanilla {
+		t.Fatalf("classify() software = %v, want %v", software, SoftwareVanilla)
+	}
+}
+
+func TestClassifyForgeFromMods(t *testing.T) {
+	software, _ := classify(nil, true)
+	if software != SoftwareForge {
+		t.Fatalf("classify() software = %v, want %v", software, SoftwareForge)
+	}
+}
+
+func TestClassifyUnknown(t *testing.T) {
+	software, confidence := classify(nil, false)
+	if software != SoftwareUnknown || confidence != 0 {
+		t.Fatalf("classify() = (%v, %v), want (%v, 0)", software, confidence, SoftwareUnknown)
+	}
+}
+
+func TestDisconnectReasonTextString(t *testing.T) {
+	if got := disconnectReasonText([]byte(`"Outdated client!"`)); got != "Outdated client!" {
+		t.Fatalf("disconnectReasonText() = %q, want %q", got, "Outdated client!")
+	}
+}
+
+func TestDisconnectReasonTextObjectWithText(t *testing.T) {
+	got := disconnectReasonText([]byte(`{"text":"Outdated client!"}`))
+	if got != "Outdated client!" {
+		t.Fatalf("disconnectReasonText() = %q, want %q", got, "Outdated client!")
+	}
+}
+
+func TestDisconnectReasonTextObjectWithTranslate(t *testing.T) {
+	got := disconnectReasonText([]byte(`{"translate":"multiplayer.disconnect.outdated_client"}`))
+	if got != "multiplayer.disconnect.outdated_client" {
+		t.Fatalf("disconnectReasonText() = %q, want translate key", got)
+	}
+}
+
+func TestVersionNameEvidence(t *testing.T) {
+	evidence := versionNameEvidence("Paper 1.20.1")
+	if len(evidence) != 1 {
+		t.Fatalf("versionNameEvidence() = %v, want exactly one match", evidence)
+	}
+}
+
+func TestForgeModInfoEvidenceFromModInfo(t *testing.T) {
+	resp := minecraftping.Response{
+		ModInfo: json.RawMessage(`{"modList":[{"modid":"examplemod"}]}`),
+	}
+
+	mods, ok := forgeModInfoEvidence(resp)
+	if !ok {
+		t.Fatal("forgeModInfoEvidence() ok = false, want true")
+	}
+	if len(mods) != 1 || mods[0] != "examplemod" {
+		t.Fatalf("forgeModInfoEvidence() mods = %v, want [examplemod]", mods)
+	}
+}
+
+func TestForgeModInfoEvidenceFromForgeData(t *testing.T) {
+	resp := minecraftping.Response{
+		ForgeData: json.RawMessage(`{"fmlNetworkVersion":3}`),
+	}
+
+	_, ok := forgeModInfoEvidence(resp)
+	if !ok {
+		t.Fatal("forgeModInfoEvidence() ok = false, want true")
+	}
+}
+
+func TestForgeModInfoEvidenceAbsent(t *testing.T) {
+	_, ok := forgeModInfoEvidence(minecraftping.Response{})
+	if ok {
+		t.Fatal("forgeModInfoEvidence() ok = true, want false")
+	}
+}
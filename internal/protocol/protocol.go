@@ -0,0 +1,123 @@
+// Package protocol implements the low-level packet framing shared by the minecraftping packages: the
+// varint-prefixed packet format used by every Java Edition connection state (handshake, status, login).
+// More information: https://wiki.vg/Protocol
+package protocol
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strconv"
+)
+
+// HandshakeNextState values, as written in the Handshake packet.
+// More information: https://wiki.vg/Protocol#Handshake
+const (
+	NextStateStatus = 1
+	NextStateLogin  = 2
+)
+
+// MakeHandshakePacket constructs a Handshake packet for the given server address, port and protocol version.
+// nextState selects whether the following packets belong to the Status or Login state.
+func MakeHandshakePacket(address string, port uint16, protocolVersion int, nextState int32) []byte {
+	var buf bytes.Buffer
+
+	PutVarInt(&buf, 0)
+	PutVarInt(&buf, int32(protocolVersion))
+
+	PutVarInt(&buf, int32(len(address)))
+	buf.WriteString(address)
+
+	binary.Write(&buf, binary.BigEndian, port)
+
+	PutVarInt(&buf, nextState)
+
+	return framePacket(buf.Bytes())
+}
+
+// MakePacket constructs a generic varint length-prefixed packet with the given packet ID and payload.
+func MakePacket(packetId int32, payload []byte) []byte {
+	var buf bytes.Buffer
+
+	PutVarInt(&buf, packetId)
+	buf.Write(payload)
+
+	return framePacket(buf.Bytes())
+}
+
+// PutString writes a Minecraft String: a varint length prefix (in bytes) followed by the UTF-8 bytes.
+func PutString(buf *bytes.Buffer, s string) {
+	PutVarInt(buf, int32(len(s)))
+	buf.WriteString(s)
+}
+
+// ReadPacket reads a single varint length-prefixed packet and returns its packet ID and payload. It reads exactly
+// the declared length via io.ReadFull, so a connection closing early mid-packet surfaces as an error rather than
+// a silently short payload.
+func ReadPacket(reader *bufio.Reader) (packetId uint64, payload []byte, err error) {
+	length, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	framed := make([]byte, length)
+	if _, err := io.ReadFull(reader, framed); err != nil {
+		return 0, nil, err
+	}
+
+	framedReader := bufio.NewReader(bytes.NewReader(framed))
+
+	packetId, err = binary.ReadUvarint(framedReader)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	payload, err = io.ReadAll(framedReader)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return packetId, payload, nil
+}
+
+// framePacket prepends b with its own length, encoded as a varint.
+func framePacket(b []byte) []byte {
+	var out bytes.Buffer
+
+	PutVarInt(&out, int32(len(b)))
+	out.Write(b)
+
+	return out.Bytes()
+}
+
+// PutVarInt allocates a []byte buffer of binary.MaxVarintLen32 and writes value as a uvarint32. Trims and writes to buf.
+func PutVarInt(buf *bytes.Buffer, value int32) {
+	b := make([]byte, binary.MaxVarintLen32)
+	bytesWritten := binary.PutUvarint(b, uint64(value))
+
+	buf.Write(b[:bytesWritten])
+}
+
+// Addr formats an address and port as a "host:port" dial string.
+func Addr(address string, port uint16) string {
+	return address + ":" + strconv.Itoa(int(port))
+}
+
+// ReadVarIntPrefixedBytes decodes a varint length prefix from the start of data and returns the following
+// length bytes. Used to unwrap Minecraft Strings embedded within an already-framed packet payload.
+func ReadVarIntPrefixedBytes(data []byte) ([]byte, error) {
+	reader := bufio.NewReader(bytes.NewReader(data))
+
+	length, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, length)
+	if _, err := io.ReadFull(reader, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
@@ -0,0 +1,50 @@
+package protocol
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestReadPacketRoundTrip(t *testing.T) {
+	packet := MakePacket(0, []byte("hello"))
+
+	packetId, payload, err := ReadPacket(bufio.NewReader(bytes.NewReader(packet)))
+	if err != nil {
+		t.Fatalf("ReadPacket() error = %v", err)
+	}
+	if packetId != 0 || string(payload) != "hello" {
+		t.Fatalf("ReadPacket() = (%d, %q), want (0, %q)", packetId, payload, "hello")
+	}
+}
+
+// TestReadPacketTruncated is a regression test: a connection that closes mid-payload (fewer bytes available than
+// the declared packet length) must surface as an error, not a silently short payload.
+func TestReadPacketTruncated(t *testing.T) {
+	packet := MakePacket(0, []byte("hello world"))
+
+	// Chop off the tail of the framed packet, simulating a connection that closes early.
+	truncated := packet[:len(packet)-4]
+
+	_, _, err := ReadPacket(bufio.NewReader(bytes.NewReader(truncated)))
+	if err == nil {
+		t.Fatal("ReadPacket() on truncated input error = nil, want io.ErrUnexpectedEOF")
+	}
+	if err != io.ErrUnexpectedEOF && err != io.EOF {
+		t.Fatalf("ReadPacket() error = %v, want an EOF-class error", err)
+	}
+}
+
+func TestReadVarIntPrefixedBytes(t *testing.T) {
+	var buf bytes.Buffer
+	PutString(&buf, `{"ok":true}`)
+
+	out, err := ReadVarIntPrefixedBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("ReadVarIntPrefixedBytes() error = %v", err)
+	}
+	if string(out) != `{"ok":true}` {
+		t.Fatalf("ReadVarIntPrefixedBytes() = %q, want %q", out, `{"ok":true}`)
+	}
+}
@@ -0,0 +1,197 @@
+package minecraftping
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/Cryptkeeper/go-minecraftping/internal/protocol"
+)
+
+// legacyProtocolVersion is sent as the protocol version byte in a 1.6 MC|PingHost payload. Servers ignore it for
+// anything other than deciding whether to include the modern fields in their reply.
+const legacyProtocolVersion = 74
+
+// LegacyResponse is a representation of the server list ping response from a pre-1.7 (Beta 1.8 through 1.6.4)
+// Minecraft Java Edition server.
+// More information: https://wiki.vg/Server_List_Ping#Legacy
+type LegacyResponse struct {
+	// Protocol and Version are only populated by the 1.6 ping variant; 1.4/1.5 and 1.3 servers don't report them.
+	Protocol int
+	Version  string
+	MOTD     string
+	Online   int
+	Max      int
+}
+
+// PingLegacy connects and pings a pre-1.7 Minecraft Java Edition server, which doesn't speak the modern
+// handshake+status flow. It tries the 1.6 ping first, then falls back to the simpler 1.4/1.5 and 1.3 variants,
+// splitting timeout evenly between the attempts.
+// More information: https://wiki.vg/Server_List_Ping#Legacy
+func PingLegacy(address string, port uint16, timeout time.Duration) (LegacyResponse, error) {
+	variants := []func(string, uint16, time.Duration) (LegacyResponse, error){
+		pingLegacy16,
+		pingLegacy14,
+		pingLegacy13,
+	}
+
+	perAttempt := timeout / time.Duration(len(variants))
+
+	var lastErr error
+	for _, variant := range variants {
+		resp, err := variant(address, port, perAttempt)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+	}
+
+	return LegacyResponse{}, lastErr
+}
+
+// pingLegacy16 implements the 1.6 Server List Ping, which is the only legacy variant that carries the target
+// host and port, letting it transit plugin-message-aware proxies the same way the modern protocol does.
+func pingLegacy16(address string, port uint16, timeout time.Duration) (LegacyResponse, error) {
+	conn, err := net.DialTimeout("tcp", protocol.Addr(address, port), timeout)
+	if err != nil {
+		return LegacyResponse{}, err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return LegacyResponse{}, err
+	}
+
+	var rest bytes.Buffer
+	rest.WriteByte(legacyProtocolVersion)
+	writeUTF16BEString(&rest, address)
+	binary.Write(&rest, binary.BigEndian, int32(port))
+
+	var packet bytes.Buffer
+	packet.WriteByte(0xfe)
+	packet.WriteByte(0x01)
+	packet.WriteByte(0xfa)
+	writeUTF16BEString(&packet, "MC|PingHost")
+	binary.Write(&packet, binary.BigEndian, uint16(rest.Len()))
+	packet.Write(rest.Bytes())
+
+	if _, err := conn.Write(packet.Bytes()); err != nil {
+		return LegacyResponse{}, err
+	}
+
+	return readLegacyResponse(conn, true)
+}
+
+// pingLegacy14 implements the 1.4/1.5 Server List Ping.
+func pingLegacy14(address string, port uint16, timeout time.Duration) (LegacyResponse, error) {
+	return pingLegacySimple(address, port, timeout, []byte{0xfe, 0x01})
+}
+
+// pingLegacy13 implements the 1.3 and earlier Server List Ping.
+func pingLegacy13(address string, port uint16, timeout time.Duration) (LegacyResponse, error) {
+	return pingLegacySimple(address, port, timeout, []byte{0xfe})
+}
+
+func pingLegacySimple(address string, port uint16, timeout time.Duration, request []byte) (LegacyResponse, error) {
+	conn, err := net.DialTimeout("tcp", protocol.Addr(address, port), timeout)
+	if err != nil {
+		return LegacyResponse{}, err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return LegacyResponse{}, err
+	}
+
+	if _, err := conn.Write(request); err != nil {
+		return LegacyResponse{}, err
+	}
+
+	return readLegacyResponse(conn, false)
+}
+
+// readLegacyResponse reads a Kick packet (0xFF) containing a UTF-16BE string and splits it into a LegacyResponse.
+// extended selects the 1.6 field layout ("§1\x00protocol\x00version\x00motd\x00online\x00max"); otherwise the
+// simpler 1.3/1.4/1.5 layout ("motd§online§max") is assumed.
+func readLegacyResponse(conn net.Conn, extended bool) (LegacyResponse, error) {
+	var resp LegacyResponse
+
+	reader := bufio.NewReader(conn)
+
+	packetId, err := reader.ReadByte()
+	if err != nil {
+		return resp, err
+	}
+	if packetId != 0xff {
+		return resp, fmt.Errorf("received invalid packetId (expected 0xff!) %#x", packetId)
+	}
+
+	var length uint16
+	if err := binary.Read(reader, binary.BigEndian, &length); err != nil {
+		return resp, err
+	}
+
+	units := make([]uint16, length)
+	if err := binary.Read(reader, binary.BigEndian, &units); err != nil {
+		return resp, err
+	}
+	text := string(utf16.Decode(units))
+
+	if extended && strings.HasPrefix(text, "§1\x00") {
+		fields := strings.Split(text, "\x00")
+		if len(fields) < 6 {
+			return resp, fmt.Errorf("received malformed legacy response: %q", text)
+		}
+		resp.Protocol, _ = strconv.Atoi(fields[1])
+		resp.Version = fields[2]
+		resp.MOTD = fields[3]
+		resp.Online, _ = strconv.Atoi(fields[4])
+		resp.Max, _ = strconv.Atoi(fields[5])
+		return resp, nil
+	}
+
+	fields := strings.Split(text, "§")
+	if len(fields) < 3 {
+		return resp, fmt.Errorf("received malformed legacy response: %q", text)
+	}
+	resp.MOTD = fields[0]
+	resp.Online, _ = strconv.Atoi(fields[1])
+	resp.Max, _ = strconv.Atoi(fields[2])
+
+	return resp, nil
+}
+
+func writeUTF16BEString(buf *bytes.Buffer, s string) {
+	units := utf16.Encode([]rune(s))
+
+	binary.Write(buf, binary.BigEndian, uint16(len(units)))
+	binary.Write(buf, binary.BigEndian, units)
+}
+
+// AutoResponse is the result of PingAuto: exactly one of Modern or Legacy is populated, depending on which
+// protocol the server answered to.
+type AutoResponse struct {
+	Modern *Response
+	Legacy *LegacyResponse
+}
+
+// PingAuto pings the Minecraft Java Edition server at address and port, trying the modern handshake+status flow
+// first and falling back to PingLegacy for servers running 1.6 and older.
+func PingAuto(address string, port uint16, protocolVersion int, timeout time.Duration) (AutoResponse, error) {
+	if resp, err := Ping(address, port, protocolVersion, timeout); err == nil {
+		return AutoResponse{Modern: &resp}, nil
+	}
+
+	resp, err := PingLegacy(address, port, timeout)
+	if err != nil {
+		return AutoResponse{}, err
+	}
+
+	return AutoResponse{Legacy: &resp}, nil
+}
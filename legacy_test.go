@@ -0,0 +1,93 @@
+package minecraftping
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+	"unicode/utf16"
+)
+
+// writeLegacyKickPacket writes a 0xFF Kick packet containing text encoded as UTF-16BE, as a legacy server would,
+// and reports any write error on errCh rather than failing the test directly (this runs on its own goroutine).
+func writeLegacyKickPacket(conn net.Conn, text string, errCh chan<- error) {
+	var buf bytes.Buffer
+	buf.WriteByte(0xff)
+	units := utf16.Encode([]rune(text))
+	binary.Write(&buf, binary.BigEndian, uint16(len(units)))
+	binary.Write(&buf, binary.BigEndian, units)
+
+	_, err := conn.Write(buf.Bytes())
+	errCh <- err
+}
+
+func TestReadLegacyResponseExtended(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+
+	errCh := make(chan error, 1)
+	go writeLegacyKickPacket(server, "§1\x0074\x001.6.4\x00A Server\x003\x0020", errCh)
+
+	resp, err := readLegacyResponse(client, true)
+	if err != nil {
+		t.Fatalf("readLegacyResponse() error = %v", err)
+	}
+	if err := <-errCh; err != nil {
+		t.Fatalf("writeLegacyKickPacket() error = %v", err)
+	}
+	if resp.Protocol != 74 || resp.Version != "1.6.4" || resp.MOTD != "A Server" || resp.Online != 3 || resp.Max != 20 {
+		t.Fatalf("readLegacyResponse() = %+v, unexpected fields", resp)
+	}
+}
+
+func TestReadLegacyResponseSimple(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+
+	errCh := make(chan error, 1)
+	go writeLegacyKickPacket(server, "A Server§5§20", errCh)
+
+	resp, err := readLegacyResponse(client, false)
+	if err != nil {
+		t.Fatalf("readLegacyResponse() error = %v", err)
+	}
+	if err := <-errCh; err != nil {
+		t.Fatalf("writeLegacyKickPacket() error = %v", err)
+	}
+	if resp.MOTD != "A Server" || resp.Online != 5 || resp.Max != 20 {
+		t.Fatalf("readLegacyResponse() = %+v, unexpected fields", resp)
+	}
+}
+
+func TestReadLegacyResponseMalformed(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+
+	errCh := make(chan error, 1)
+	go writeLegacyKickPacket(server, "not enough fields", errCh)
+
+	_, err := readLegacyResponse(client, false)
+	if err == nil {
+		t.Fatal("readLegacyResponse() error = nil, want malformed-response error")
+	}
+	<-errCh
+}
+
+func TestWriteUTF16BEStringRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	writeUTF16BEString(&buf, "example.com")
+
+	var length uint16
+	if err := binary.Read(&buf, binary.BigEndian, &length); err != nil {
+		t.Fatalf("binary.Read() error = %v", err)
+	}
+
+	units := make([]uint16, length)
+	if err := binary.Read(&buf, binary.BigEndian, &units); err != nil {
+		t.Fatalf("binary.Read() error = %v", err)
+	}
+
+	if got := string(utf16.Decode(units)); got != "example.com" {
+		t.Fatalf("decoded string = %q, want %q", got, "example.com")
+	}
+}
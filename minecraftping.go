@@ -2,15 +2,12 @@
 package minecraftping
 
 import (
-	"bufio"
-	"bytes"
-	"encoding/binary"
 	"encoding/json"
-	"fmt"
-	"io"
-	"net"
-	"strconv"
 	"time"
+
+	"github.com/Cryptkeeper/go-minecraftping/address"
+	"github.com/Cryptkeeper/go-minecraftping/chat"
+	"github.com/Cryptkeeper/go-minecraftping/internal/protocol"
 )
 
 const (
@@ -45,94 +42,67 @@ type Response struct {
 	} `json:"players"`
 	Description json.RawMessage `json:"description"`
 	Favicon     string          `json:"favicon"`
+
+	// ModInfo is populated by pre-1.13 Forge servers under the "modinfo" key.
+	ModInfo json.RawMessage `json:"modinfo,omitempty"`
+
+	// ForgeData is populated by modern Forge servers under the "forgeData" key, per the FML2 handshake extension
+	// to the status response.
+	// More information: https://wiki.vg/Minecraft_Forge_Handshake
+	ForgeData json.RawMessage `json:"forgeData,omitempty"`
+}
+
+// DescriptionComponent parses Description as a chat component, accepting either the legacy plain-string form or
+// the modern object/array form servers may return.
+func (r Response) DescriptionComponent() (*chat.Component, error) {
+	var c chat.Component
+	if err := json.Unmarshal(r.Description, &c); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
 }
 
 // Ping connects and pings the Minecraft Java Edition server at the specified address and port.
 // protocolVersion dictates which protocol version to attempt the ping with as the response is protocol version dependent.
+// It's a thin wrapper around Client for callers that just want a one-shot status request.
 // More information: https://wiki.vg/Server_List_Ping
 func Ping(address string, port uint16, protocolVersion int, timeout time.Duration) (Response, error) {
-	var resp Response
-
-	deadline := time.Now().Add(timeout)
-
-	conn, err := net.DialTimeout("tcp", address+":"+strconv.Itoa(int(port)), timeout)
+	client, err := NewClient(protocol.Addr(address, port),
+		WithTimeout(timeout),
+		WithProtocolVersion(protocolVersion),
+		WithSRV(false),
+	)
 	if err != nil {
-		return resp, err
+		return Response{}, err
 	}
-	defer conn.Close()
+	defer client.Close()
 
-	if err := conn.SetDeadline(deadline); err != nil {
-		return resp, err
-	}
-
-	// Construct and write Handshake packet to open connection and then write Request packet.
-	// More information: https://wiki.vg/Server_List_Ping
-	handshake := makeHandshakePacket(address, port, protocolVersion)
-	conn.Write(handshake)
-
-	conn.Write(requestPacket)
-
-	reader := bufio.NewReader(conn)
+	return client.Status()
+}
 
-	// Read and discard the length of the incoming packet.
-	_, err = binary.ReadUvarint(reader)
+// PingAddress resolves addr via address.Resolve, following any "_minecraft._tcp" SRV record, and pings the
+// resulting host and port. Unlike Ping, addr may be a bare domain without a port.
+//
+// The Handshake packet's server address field carries addr's original hostname rather than the SRV-resolved
+// dial target, so that reverse proxies keyed on the virtual host (Velocity/BungeeCord) still route correctly.
+// More information: https://wiki.vg/Server_List_Ping#Current
+func PingAddress(addr string, protocolVersion int, timeout time.Duration) (Response, error) {
+	target, err := address.Resolve(nil, addr, DefaultPort)
 	if err != nil {
-		return resp, err
+		return Response{}, err
 	}
 
-	// Read the packet ID and validate it as 0.
-	packetId, err := binary.ReadUvarint(reader)
+	client, err := NewClient(protocol.Addr(target.DialHost, target.Port),
+		WithTimeout(timeout),
+		WithProtocolVersion(protocolVersion),
+		WithVirtualHost(target.Host),
+		WithSRV(false),
+	)
 	if err != nil {
-		return resp, err
-	}
-	if packetId != 0 {
-		return resp, fmt.Errorf("received invalid packetId (expected 0!) %d", packetId)
+		return Response{}, err
 	}
+	defer client.Close()
 
-	// Read the length of the incoming JSON payload (as a uvarint). Read the following bytes into a buffer and then
-	// unmarshal the []byte into its struct representation Response.
-	length, err := binary.ReadUvarint(reader)
-	if err != nil {
-		return resp, err
-	}
-	payload := make([]byte, length)
-	if _, err = io.ReadFull(reader, payload); err != nil {
-		return resp, err
-	}
-	if err = json.Unmarshal(payload, &resp); err != nil {
-		return resp, err
-	}
-
-	return resp, nil
-}
-
-func makeHandshakePacket(address string, port uint16, protocolVersion int) []byte {
-	var buf bytes.Buffer
-
-	buf.Write([]byte("\x00"))
-
-	putVarInt(&buf, int32(protocolVersion))
-
-	putVarInt(&buf, int32(len(address)))
-	buf.WriteString(address)
-
-	binary.Write(&buf, binary.BigEndian, port)
-
-	putVarInt(&buf, 1)
-
-	// Prepend the buffer with it's length as a uvarint
-	var out bytes.Buffer
-
-	putVarInt(&out, int32(buf.Len()))
-	out.Write(buf.Bytes())
-
-	return out.Bytes()
-}
-
-// Allocate a []byte buffer of binary.MaxVarintlen32 and write value as a uvarint32. Trim and write to buf.
-func putVarInt(buf *bytes.Buffer, value int32) {
-	bytes := make([]byte, binary.MaxVarintLen32)
-	bytesWritten := binary.PutUvarint(bytes, uint64(value))
-
-	buf.Write(bytes[:bytesWritten])
+	return client.Status()
 }